@@ -0,0 +1,133 @@
+package sendtables
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	r3 "github.com/golang/geo/r3"
+)
+
+// demoPropTag is the struct tag read by BindStruct to map a field to a
+// networked property.
+//
+// Format: `demoprop:"m_iHealth"` or `demoprop:"m_iAmmo,array"` /
+// `demoprop:"m_bIsScoped,boolint"`. The modifiers disambiguate fields whose
+// Go kind doesn't map 1:1 onto a propertyValueType (see inferValueType).
+const demoPropTag = "demoprop"
+
+// BindStruct walks target (which must be a pointer to a struct) and binds
+// every field tagged with `demoprop:"..."` to the correspondingly named
+// Property via FindProperty() & Property.Bind(), inferring the
+// propertyValueType from the field's Go type.
+//
+// Embedded structs are walked recursively, by value or by pointer (a nil
+// embedded pointer is allocated before recursing), so a target can be
+// composed from reusable pieces (e.g. a common "Position" struct embedded
+// in both a Player and a Weapon model). Fields without a demoprop tag are
+// skipped.
+//
+// Panics if target isn't a pointer to a struct, if a tagged field is
+// unexported (there's no way to bind a pointer to it), if a tagged property
+// can't be found via FindProperty, or if the field's type can't be mapped
+// to a propertyValueType (see the ",boolint" / ",array" modifiers below).
+func (e *Entity) BindStruct(target interface{}) {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("BindStruct: target must be a pointer to a struct, got %T", target))
+	}
+
+	e.bindStructValue(v.Elem())
+}
+
+func (e *Entity) bindStructValue(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := v.Field(i)
+
+		if field.Anonymous {
+			if fieldVal.Kind() == reflect.Struct {
+				e.bindStructValue(fieldVal)
+				continue
+			}
+			if fieldVal.Kind() == reflect.Ptr && fieldVal.Type().Elem().Kind() == reflect.Struct {
+				if fieldVal.IsNil() {
+					fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+				}
+				e.bindStructValue(fieldVal.Elem())
+				continue
+			}
+		}
+
+		tag, ok := field.Tag.Lookup(demoPropTag)
+		if !ok {
+			continue
+		}
+
+		if field.PkgPath != "" {
+			panic(fmt.Sprintf("BindStruct: field %s has a demoprop tag but is unexported and can't be bound", field.Name))
+		}
+
+		propName, modifier := parseDemoPropTag(tag)
+
+		prop := e.FindProperty(propName)
+		if prop == nil {
+			panic(fmt.Sprintf("BindStruct: no property named %q found for field %s", propName, field.Name))
+		}
+
+		valueType := inferValueType(field.Type, modifier)
+
+		prop.Bind(fieldVal.Addr().Interface(), valueType)
+	}
+}
+
+func parseDemoPropTag(tag string) (propName, modifier string) {
+	parts := strings.SplitN(tag, ",", 2)
+	propName = parts[0]
+	if len(parts) == 2 {
+		modifier = parts[1]
+	}
+	return
+}
+
+var vectorType = reflect.TypeOf(r3.Vector{})
+var propertyValueSliceType = reflect.TypeOf([]PropertyValue{})
+
+// inferValueType maps a struct field's Go type (plus an optional tag
+// modifier for ambiguous cases) onto a propertyValueType understood by
+// Property.Bind.
+func inferValueType(t reflect.Type, modifier string) propertyValueType {
+	switch modifier {
+	case "boolint":
+		if t.Kind() != reflect.Bool {
+			panic(fmt.Sprintf("BindStruct: ,boolint modifier used on non-bool field of type %s", t))
+		}
+		return ValTypeBoolInt
+	case "array":
+		if t != propertyValueSliceType {
+			panic(fmt.Sprintf("BindStruct: ,array modifier used on field of type %s, want []PropertyValue", t))
+		}
+		return ValTypeArray
+	}
+
+	switch {
+	case t == vectorType:
+		return ValTypeVector
+	case t == propertyValueSliceType:
+		return ValTypeArray
+	case t.Kind() == reflect.Bool:
+		return ValTypeBoolInt
+	case t.Kind() == reflect.Int:
+		return ValTypeInt
+	case t.Kind() == reflect.Float32:
+		return ValTypeFloat32
+	case t.Kind() == reflect.Float64:
+		return ValTypeFloat64
+	case t.Kind() == reflect.String:
+		return ValTypeString
+	default:
+		panic(fmt.Sprintf("BindStruct: cannot infer property value type for field of type %s", t))
+	}
+}
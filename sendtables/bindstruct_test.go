@@ -0,0 +1,120 @@
+package sendtables
+
+import (
+	"testing"
+
+	r3 "github.com/golang/geo/r3"
+)
+
+type TestPosition struct {
+	Origin r3.Vector `demoprop:"m_vecOrigin"`
+}
+
+type testPlayer struct {
+	TestPosition
+	*TestWeapons
+	Health int  `demoprop:"m_iHealth"`
+	Scoped bool `demoprop:"m_bIsScoped,boolint"`
+}
+
+type TestWeapons struct {
+	Ammo []PropertyValue `demoprop:"m_iAmmo,array"`
+}
+
+func newBindStructTestEntity() *Entity {
+	return &Entity{
+		serverClass: &ServerClass{name: "TestClass"},
+		props: []Property{
+			{entry: &flattenedPropEntry{name: "m_vecOrigin"}, value: PropertyValue{VectorVal: r3.Vector{X: 1, Y: 2, Z: 3}}},
+			{entry: &flattenedPropEntry{name: "m_iHealth"}, value: PropertyValue{IntVal: 80}},
+			{entry: &flattenedPropEntry{name: "m_bIsScoped"}, value: PropertyValue{IntVal: 1}},
+			{entry: &flattenedPropEntry{name: "m_iAmmo"}, value: PropertyValue{ArrayVal: []PropertyValue{{IntVal: 30}}}},
+		},
+	}
+}
+
+func TestBindStructSimpleFields(t *testing.T) {
+	e := newBindStructTestEntity()
+
+	var target struct {
+		Health int `demoprop:"m_iHealth"`
+	}
+	e.BindStruct(&target)
+
+	// Bind only registers a handler for future updates; fire one to populate it.
+	for i := range e.props {
+		e.props[i].firePropertyUpdate()
+	}
+
+	if target.Health != 80 {
+		t.Fatalf("target.Health = %d, want 80", target.Health)
+	}
+}
+
+func TestBindStructEmbeddedValueAndPointer(t *testing.T) {
+	e := newBindStructTestEntity()
+
+	var p testPlayer
+	p.TestWeapons = &TestWeapons{}
+	e.BindStruct(&p)
+
+	for i := range e.props {
+		e.props[i].firePropertyUpdate()
+	}
+
+	if p.Origin != (r3.Vector{X: 1, Y: 2, Z: 3}) {
+		t.Errorf("p.Origin = %v, want {1 2 3}", p.Origin)
+	}
+	if p.Health != 80 {
+		t.Errorf("p.Health = %d, want 80", p.Health)
+	}
+	if !p.Scoped {
+		t.Errorf("p.Scoped = false, want true")
+	}
+	if len(p.Ammo) != 1 || p.Ammo[0].IntVal != 30 {
+		t.Errorf("p.Ammo = %v, want one entry with IntVal 30", p.Ammo)
+	}
+}
+
+func TestBindStructAllocatesNilEmbeddedPointer(t *testing.T) {
+	e := newBindStructTestEntity()
+
+	var p testPlayer
+	e.BindStruct(&p)
+
+	if p.TestWeapons == nil {
+		t.Fatal("BindStruct left nil embedded pointer struct unallocated")
+	}
+}
+
+type testUnexported struct {
+	health int `demoprop:"m_iHealth"` //nolint:unused
+}
+
+func TestBindStructPanicsOnUnexportedTaggedField(t *testing.T) {
+	e := newBindStructTestEntity()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected BindStruct to panic on an unexported tagged field")
+		}
+	}()
+
+	var target testUnexported
+	e.BindStruct(&target)
+}
+
+func TestBindStructPanicsOnMissingProperty(t *testing.T) {
+	e := newBindStructTestEntity()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected BindStruct to panic when the tagged property doesn't exist")
+		}
+	}()
+
+	var target struct {
+		Armor int `demoprop:"m_iArmor"`
+	}
+	e.BindStruct(&target)
+}
@@ -0,0 +1,48 @@
+package sendtables
+
+import (
+	bit "github.com/markus-wa/demoinfocs-golang/bitread"
+)
+
+// PropertyDecoderFunc decodes a single property's raw value off the wire.
+// It replaces propDecoder.decodeProp for properties registered via
+// ServerClass.RegisterPropertyDecoder, letting callers teach the library
+// about non-stock Source-engine networked types without forking the
+// decoder.
+type PropertyDecoderFunc func(reader *bit.BitReader) PropertyValue
+
+// RegisterPropertyDecoder overrides the decoder used for the property at
+// propPath (a flattened prop name, as returned by Property.Name()) on this
+// server-class. It must be called before parsing starts - registrations are
+// resolved lazily the first time each Entity's matching Property is decoded
+// and cached from then on. Properties nobody overrides still pay one map
+// lookup on their first decode and a cached check on every decode after
+// that; the lookup is not done when the prop table is flattened.
+func (sc *ServerClass) RegisterPropertyDecoder(propPath string, decoder PropertyDecoderFunc) {
+	sc.customDecodersMu.Lock()
+	defer sc.customDecodersMu.Unlock()
+
+	if sc.customDecoders == nil {
+		sc.customDecoders = make(map[string]PropertyDecoderFunc)
+	}
+	sc.customDecoders[propPath] = decoder
+}
+
+func (sc *ServerClass) customPropertyDecoder(propPath string) PropertyDecoderFunc {
+	sc.customDecodersMu.RLock()
+	defer sc.customDecodersMu.RUnlock()
+
+	return sc.customDecoders[propPath]
+}
+
+// resolveCustomDecoder looks up and caches the PropertyDecoderFunc
+// registered for this property's path on sc, if any. The lookup only
+// happens once per Property; the result (including the "no override"
+// case) is cached on the Property itself.
+func (pe *Property) resolveCustomDecoder(sc *ServerClass) PropertyDecoderFunc {
+	if !pe.customDecoderResolved {
+		pe.customDecoder = sc.customPropertyDecoder(pe.entry.name)
+		pe.customDecoderResolved = true
+	}
+	return pe.customDecoder
+}
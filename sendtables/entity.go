@@ -3,6 +3,7 @@ package sendtables
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	r3 "github.com/golang/geo/r3"
 
@@ -71,6 +72,18 @@ var updatedPropIndicesPool = sync.Pool{
 //
 // Intended for internal use only.
 func (e *Entity) ApplyUpdate(reader *bit.BitReader) {
+	// Instrumentation (timing, bits-read) is only computed when this
+	// entity's server-class has a MetricsCollector installed via
+	// ServerClass.SetMetricsCollector, so parsing without one pays nothing
+	// for it - this runs once per entity per packet and the per-property
+	// decode loop below is the hottest loop in the library.
+	instrumented := e.serverClass.metricsCollector != nil
+
+	var startPos int
+	if instrumented {
+		startPos = reader.ActualPos()
+	}
+
 	idx := -1
 	newWay := reader.ReadBit()
 	updatedPropIndices := updatedPropIndicesPool.Get().(*[]int)
@@ -79,9 +92,50 @@ func (e *Entity) ApplyUpdate(reader *bit.BitReader) {
 		*updatedPropIndices = append(*updatedPropIndices, idx)
 	}
 
+	var serverClassName string
+	if instrumented {
+		serverClassName = e.serverClass.Name()
+	}
+
 	for _, idx := range *updatedPropIndices {
-		propDecoder.decodeProp(&e.props[idx], reader)
-		e.props[idx].firePropertyUpdate()
+		prop := &e.props[idx]
+
+		// Like the metrics instrumentation above, a property's oldValue and
+		// the current tick are only worth computing if this property's
+		// journal is actually enabled - otherwise recordChange is a no-op
+		// and the work below it would be wasted on every single property of
+		// every single update.
+		journaling := prop.journal != nil
+
+		var oldValue PropertyValue
+		if journaling {
+			oldValue = prop.value
+		}
+
+		var decodeStart time.Time
+		if instrumented {
+			decodeStart = time.Now()
+		}
+
+		if dec := prop.resolveCustomDecoder(e.serverClass); dec != nil {
+			prop.value = dec(reader)
+		} else {
+			propDecoder.decodeProp(prop, reader)
+		}
+
+		if instrumented {
+			e.serverClass.metricsCollector.PropertyDecoded(serverClassName, prop.entry.name, time.Since(decodeStart))
+		}
+
+		prop.firePropertyUpdate()
+
+		if journaling {
+			prop.recordChange(e, e.serverClass.tick(), oldValue, prop.value)
+		}
+	}
+
+	if instrumented {
+		e.serverClass.metricsCollector.EntityUpdated(serverClassName, len(*updatedPropIndices), reader.ActualPos()-startPos)
 	}
 
 	// Reset length to 0 before pooling
@@ -192,9 +246,13 @@ func (e *Entity) OnCreateFinished(delegate func()) {
 // Property wraps a flattenedPropEntry and allows registering handlers
 // that can be triggered on a update of the property.
 type Property struct {
-	entry          *flattenedPropEntry
-	updateHandlers []PropertyUpdateHandler
-	value          PropertyValue
+	entry                 *flattenedPropEntry
+	updateHandlers        []PropertyUpdateHandler
+	value                 PropertyValue
+	journal               *journal
+	journalSink           JournalSink
+	customDecoder         PropertyDecoderFunc
+	customDecoderResolved bool
 }
 
 // Name returns the property's name.
@@ -0,0 +1,8 @@
+package sendtables
+
+// flattenedPropEntry is a single entry in a ServerClass's flattened (fully
+// resolved, array-expanded) property table - the order and set of entries
+// used to interpret the per-entity update bitstream in Entity.ApplyUpdate.
+type flattenedPropEntry struct {
+	name string
+}
@@ -0,0 +1,154 @@
+package sendtables
+
+// SetTickProvider installs the func used to stamp this server-class's
+// PropertyChanges with the current tick. Must be called before parsing
+// starts. Pass nil to reset to the default of always stamping tick 0 - a
+// service running multiple or concurrent parsers gets a distinct
+// ServerClass per parse, so each can supply its own tick source (e.g.
+// parser.GameState().IngameTick) without clobbering another's.
+func (sc *ServerClass) SetTickProvider(provider func() int) {
+	sc.tickProvider = provider
+}
+
+// tick returns the current tick to stamp a newly recorded PropertyChange
+// with, or 0 if no tick provider has been installed via SetTickProvider.
+func (sc *ServerClass) tick() int {
+	if sc.tickProvider == nil {
+		return 0
+	}
+	return sc.tickProvider()
+}
+
+// PropertyChange is a single recorded transition of a Property's value,
+// captured by a journal enabled via Property.EnableJournal.
+type PropertyChange struct {
+	Tick     int
+	OldValue PropertyValue
+	NewValue PropertyValue
+}
+
+// EntityChange is a PropertyChange paired with the Property it occurred on,
+// as returned by Entity.ChangesSince.
+type EntityChange struct {
+	Property *Property
+	PropertyChange
+}
+
+// JournalSink receives PropertyChanges as they're recorded, in addition to
+// them being buffered in the Property's own journal. Register one via
+// Property.EnableJournal to pipe the diff stream to an external store (JSON
+// lines, Kafka, SQLite, ...) for post-hoc analytics without re-parsing
+// demos.
+//
+// OnPropertyChange may be called from multiple goroutines if Entity.ApplyUpdate
+// is ever driven concurrently for different entities sharing this sink -
+// implementations must be safe for concurrent use.
+type JournalSink interface {
+	OnPropertyChange(entity *Entity, prop *Property, change PropertyChange)
+}
+
+// journal is a fixed-capacity ring buffer of PropertyChanges. Once full,
+// recording a new change drops the oldest one - memory stays bounded
+// regardless of how long a demo runs.
+type journal struct {
+	entries []PropertyChange
+	start   int // index of the oldest entry
+	size    int // number of valid entries
+}
+
+func newJournal(capacity int) *journal {
+	return &journal{entries: make([]PropertyChange, capacity)}
+}
+
+func (j *journal) record(change PropertyChange) {
+	capacity := len(j.entries)
+	if capacity == 0 {
+		return
+	}
+
+	writeIdx := (j.start + j.size) % capacity
+	j.entries[writeIdx] = change
+
+	if j.size < capacity {
+		j.size++
+	} else {
+		// Full - the write above just overwrote the oldest entry, advance start.
+		j.start = (j.start + 1) % capacity
+	}
+}
+
+func (j *journal) since(tick int) []PropertyChange {
+	capacity := len(j.entries)
+	result := make([]PropertyChange, 0, j.size)
+
+	for i := 0; i < j.size; i++ {
+		change := j.entries[(j.start+i)%capacity]
+		if change.Tick >= tick {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+// EnableJournal turns on change-journaling for this Property: every value
+// change recorded by ApplyUpdate or Restore is kept in a ring buffer of at
+// most capacity entries (oldest dropped first) and, if sink is non-nil,
+// also forwarded to it immediately.
+//
+// Changes are stamped with whatever the Property's server-class's tick
+// provider (see ServerClass.SetTickProvider) returns at the time they're
+// recorded, rather than a tick passed in here, since journaling is enabled
+// once up front while the current tick keeps advancing as the demo is
+// parsed.
+func (pe *Property) EnableJournal(capacity int, sink JournalSink) {
+	pe.journal = newJournal(capacity)
+	pe.journalSink = sink
+}
+
+// History returns all recorded changes for this property with Tick >=
+// sinceTick, oldest first. Returns nil if journaling isn't enabled via
+// EnableJournal.
+func (pe *Property) History(sinceTick int) []PropertyChange {
+	if pe.journal == nil {
+		return nil
+	}
+	return pe.journal.since(sinceTick)
+}
+
+// recordChange appends a change to the property's journal (if enabled) and
+// forwards it to the journal sink (if any). Called from Entity.ApplyUpdate
+// and Entity.Restore, right after each one fires the property's update
+// handlers.
+func (pe *Property) recordChange(entity *Entity, tick int, oldValue, newValue PropertyValue) {
+	if pe.journal == nil {
+		return
+	}
+
+	change := PropertyChange{Tick: tick, OldValue: oldValue, NewValue: newValue}
+	pe.journal.record(change)
+
+	if pe.journalSink != nil {
+		pe.journalSink.OnPropertyChange(entity, pe, change)
+	}
+}
+
+// ChangesSince returns every recorded change across all of the Entity's
+// properties with Tick >= sinceTick, in property order. Only properties
+// with journaling enabled via Property.EnableJournal contribute entries.
+func (e *Entity) ChangesSince(sinceTick int) []EntityChange {
+	var changes []EntityChange
+
+	for i := range e.props {
+		prop := &e.props[i]
+		if prop.journal == nil {
+			continue
+		}
+
+		for _, change := range prop.journal.since(sinceTick) {
+			changes = append(changes, EntityChange{Property: prop, PropertyChange: change})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,79 @@
+package sendtables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJournalRingBufferWrapsAround(t *testing.T) {
+	j := newJournal(3)
+
+	for tick := 1; tick <= 5; tick++ {
+		j.record(PropertyChange{Tick: tick, NewValue: PropertyValue{IntVal: tick}})
+	}
+
+	got := j.since(0)
+	want := []PropertyChange{
+		{Tick: 3, NewValue: PropertyValue{IntVal: 3}},
+		{Tick: 4, NewValue: PropertyValue{IntVal: 4}},
+		{Tick: 5, NewValue: PropertyValue{IntVal: 5}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("journal.since(0) = %+v, want %+v (capacity-3 ring buffer should have dropped ticks 1-2)", got, want)
+	}
+}
+
+func TestJournalSinceFiltersByTick(t *testing.T) {
+	j := newJournal(10)
+	for tick := 1; tick <= 5; tick++ {
+		j.record(PropertyChange{Tick: tick})
+	}
+
+	got := j.since(3)
+	if len(got) != 3 || got[0].Tick != 3 {
+		t.Fatalf("journal.since(3) = %+v, want 3 entries starting at tick 3", got)
+	}
+}
+
+func TestPropertyHistoryNilWithoutJournal(t *testing.T) {
+	pe := &Property{entry: &flattenedPropEntry{name: "m_iHealth"}}
+	if got := pe.History(0); got != nil {
+		t.Fatalf("History() on a property without EnableJournal = %v, want nil", got)
+	}
+}
+
+func TestEntityRestoreRecordsJournalAndFiresHandlers(t *testing.T) {
+	sc := &ServerClass{name: "TestClass"}
+	sc.SetTickProvider(func() int { return 42 })
+
+	e := &Entity{
+		serverClass: sc,
+		props: []Property{
+			{entry: &flattenedPropEntry{name: "m_iHealth"}, value: PropertyValue{IntVal: 100}},
+		},
+	}
+	e.props[0].EnableJournal(10, nil)
+
+	var observed int
+	e.props[0].OnUpdate(func(val PropertyValue) { observed = val.IntVal })
+
+	snap := e.Snapshot()
+
+	e.props[0].value = PropertyValue{IntVal: 50}
+	e.Restore(snap)
+
+	if observed != 100 {
+		t.Fatalf("OnUpdate handler observed %d after Restore, want 100", observed)
+	}
+
+	history := e.props[0].History(0)
+	if len(history) != 1 || history[0].Tick != 42 || history[0].NewValue.IntVal != 100 || history[0].OldValue.IntVal != 50 {
+		t.Fatalf("History() = %+v, want one change from 50 to 100 at tick 42", history)
+	}
+
+	changes := e.ChangesSince(0)
+	if len(changes) != 1 || changes[0].Property != &e.props[0] {
+		t.Fatalf("ChangesSince(0) = %+v, want single change pointing at m_iHealth", changes)
+	}
+}
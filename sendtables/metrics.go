@@ -0,0 +1,36 @@
+package sendtables
+
+import "time"
+
+// MetricsCollector is the extension point for observability into the
+// entity/property decoding pipeline.
+//
+// Implementations are called from the decode hot-path (Entity.ApplyUpdate,
+// around each property decode) so they must be safe for concurrent use and
+// should avoid doing expensive work inline - e.g. a Prometheus-backed
+// implementation should only touch pre-registered collectors.
+//
+// A MetricsCollector is installed per ServerClass via SetMetricsCollector,
+// not process-wide: a service parsing multiple demos (or multiple demos
+// concurrently) gets distinct ServerClass values per parse, so each can have
+// its own collector, or none at all, without them clobbering each other.
+type MetricsCollector interface {
+	// EntityUpdated is called once per Entity.ApplyUpdate call, after all
+	// updated properties have been decoded and their handlers fired.
+	EntityUpdated(serverClass string, updatedProps int, bitsRead int)
+
+	// PropertyDecoded is called for every individual property decoded as
+	// part of an update, with the time spent decoding it (in
+	// propDecoder.decodeProp, or in a decoder registered via
+	// ServerClass.RegisterPropertyDecoder if one applies).
+	PropertyDecoded(serverClass, propName string, d time.Duration)
+}
+
+// SetMetricsCollector installs the MetricsCollector used for entities
+// belonging to this server-class. It must be called before parsing starts;
+// it is not safe to change the collector while a parser is running. Pass
+// nil to disable collection (the default) - Entity.ApplyUpdate then skips
+// the instrumentation work entirely rather than calling into a no-op.
+func (sc *ServerClass) SetMetricsCollector(collector MetricsCollector) {
+	sc.metricsCollector = collector
+}
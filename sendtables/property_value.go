@@ -0,0 +1,13 @@
+package sendtables
+
+import r3 "github.com/golang/geo/r3"
+
+// PropertyValue holds a decoded property value. Only the field matching the
+// property's propertyValueType is meaningful for a given value.
+type PropertyValue struct {
+	IntVal    int
+	FloatVal  float32
+	StringVal string
+	VectorVal r3.Vector
+	ArrayVal  []PropertyValue
+}
@@ -0,0 +1,210 @@
+package sendtables
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// queryOp is a comparison operator usable in a query predicate, e.g. the
+// ">0" in "m_iAmmo[>0]".
+type queryOp int
+
+const (
+	opNone queryOp = iota
+	opEq
+	opNeq
+	opGt
+	opGte
+	opLt
+	opLte
+)
+
+var queryOps = []struct {
+	token string
+	op    queryOp
+}{
+	// Longer tokens first so e.g. ">=" isn't matched as ">" followed by "=".
+	{">=", opGte},
+	{"<=", opLte},
+	{"==", opEq},
+	{"!=", opNeq},
+	{">", opGt},
+	{"<", opLt},
+}
+
+// queryPredicate filters matched properties by comparing their IntVal
+// against a fixed operand, e.g. "[>0]".
+//
+// Predicates only support integer comparisons: PropertyValue carries no tag
+// saying which of its fields is meaningful, so there's no reliable way to
+// compare a float or vector property against an operand. Using a predicate
+// on a non-int property will compare against IntVal's zero value rather
+// than erroring - callers should only predicate-filter properties they know
+// are integer-valued (e.g. "m_iAmmo[>0]", not "m_vecOrigin[>0]").
+type queryPredicate struct {
+	op      queryOp
+	operand int
+}
+
+func (p queryPredicate) matches(val PropertyValue) bool {
+	switch p.op {
+	case opEq:
+		return val.IntVal == p.operand
+	case opNeq:
+		return val.IntVal != p.operand
+	case opGt:
+		return val.IntVal > p.operand
+	case opGte:
+		return val.IntVal >= p.operand
+	case opLt:
+		return val.IntVal < p.operand
+	case opLte:
+		return val.IntVal <= p.operand
+	default:
+		return true
+	}
+}
+
+// queryPlan is a compiled Entity.Query expression: a prop-name pattern
+// (dotted path with "*" wildcards per path component) plus an optional
+// trailing predicate. A plan never refers back to the ServerClass it was
+// compiled for - compileQuery only looks at expr - so plans are cached on
+// ServerClass.queryPlans purely to give them the same lifetime as the
+// server-class (and thus the flattened prop table they're matched against),
+// not because the compiled regexp is class-specific.
+type queryPlan struct {
+	namePattern *regexp.Regexp
+	predicate   queryPredicate
+}
+
+func (p *queryPlan) matches(prop *Property) bool {
+	if !p.namePattern.MatchString(prop.entry.name) {
+		return false
+	}
+	return p.predicate.matches(prop.value)
+}
+
+// getQueryPlan returns the compiled plan for expr, compiling and caching it
+// on sc.queryPlans if this is the first time expr has been queried against
+// this server-class. The cache is freed along with sc, so a long-running
+// service parsing many demos (and thus creating many ServerClass values)
+// never accumulates plans for classes it's done with.
+func (sc *ServerClass) getQueryPlan(expr string) (*queryPlan, error) {
+	sc.queryPlansMu.Lock()
+	defer sc.queryPlansMu.Unlock()
+
+	if plan, ok := sc.queryPlans[expr]; ok {
+		return plan, nil
+	}
+
+	plan, err := compileQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.queryPlans == nil {
+		sc.queryPlans = make(map[string]*queryPlan)
+	}
+	sc.queryPlans[expr] = plan
+
+	return plan, nil
+}
+
+// compileQuery parses expr (e.g. "m_hMyWeapons.*" or "m_iAmmo[>0]") into a
+// queryPlan.
+func compileQuery(expr string) (*queryPlan, error) {
+	namePart := expr
+	predicate := queryPredicate{op: opNone}
+
+	if open := strings.IndexByte(expr, '['); open != -1 {
+		shut := strings.IndexByte(expr, ']')
+		if shut == -1 || shut < open {
+			return nil, fmt.Errorf("sendtables: invalid query %q: unterminated predicate", expr)
+		}
+
+		namePart = expr[:open]
+		pred, err := compilePredicate(expr[open+1 : shut])
+		if err != nil {
+			return nil, fmt.Errorf("sendtables: invalid query %q: %w", expr, err)
+		}
+		predicate = pred
+	}
+
+	if namePart == "" {
+		return nil, fmt.Errorf("sendtables: invalid query %q: empty prop path", expr)
+	}
+
+	return &queryPlan{
+		namePattern: compileNamePattern(namePart),
+		predicate:   predicate,
+	}, nil
+}
+
+func compilePredicate(raw string) (queryPredicate, error) {
+	for _, candidate := range queryOps {
+		if strings.HasPrefix(raw, candidate.token) {
+			operandStr := strings.TrimSpace(raw[len(candidate.token):])
+			operand, err := strconv.Atoi(operandStr)
+			if err != nil {
+				return queryPredicate{}, fmt.Errorf("predicate operand %q is not an integer", operandStr)
+			}
+			return queryPredicate{op: candidate.op, operand: operand}, nil
+		}
+	}
+	return queryPredicate{}, fmt.Errorf("predicate %q has no recognized operator", raw)
+}
+
+// compileNamePattern turns a dotted prop path with "*" wildcards (one per
+// path component, e.g. "m_hMyWeapons.*") into an anchored regexp. "*"
+// matches exactly one component; it never matches across a ".".
+func compileNamePattern(namePart string) *regexp.Regexp {
+	components := strings.Split(namePart, ".")
+	quoted := make([]string, len(components))
+	for i, c := range components {
+		if c == "*" {
+			quoted[i] = `[^.]+`
+		} else {
+			quoted[i] = regexp.QuoteMeta(c)
+		}
+	}
+	return regexp.MustCompile("^" + strings.Join(quoted, `\.`) + "$")
+}
+
+// Query returns every Property on the Entity whose flattened name matches
+// expr, optionally filtered by a trailing predicate.
+//
+// expr is a dotted path matched against each property's flattened name (as
+// returned by Property.Name()) component by component, where any component
+// may be "*" to match all properties at that position (e.g.
+// "m_hMyWeapons.*" to match every element of an array-valued property), and
+// may end with a bracketed integer comparison predicate (e.g. "m_iAmmo[>0]",
+// see queryPredicate). expr only ever matches real flattened prop names -
+// there is no support for addressing a vector property's X/Y/Z components
+// as sub-paths (e.g. "m_vecOrigin.X" matches nothing, since "m_vecOrigin.X"
+// isn't itself a flattened prop name); read Property.Value().VectorVal on
+// the matched "m_vecOrigin" property instead.
+//
+// Unlike FindProperty, Query never panics on ambiguous names - a plain name
+// with no wildcard or predicate exact-matches like FindProperty but returns
+// a (possibly empty or multi-element) slice instead of panicking.
+//
+// Compiled expressions are cached on the Entity's server-class, so calling
+// Query with the same expr repeatedly (e.g. once per tick) only compiles it
+// once and the cache is freed along with the server-class.
+func (e *Entity) Query(expr string) ([]*Property, error) {
+	plan, err := e.serverClass.getQueryPlan(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Property
+	for i := range e.props {
+		if plan.matches(&e.props[i]) {
+			matches = append(matches, &e.props[i])
+		}
+	}
+
+	return matches, nil
+}
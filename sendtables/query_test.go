@@ -0,0 +1,125 @@
+package sendtables
+
+import "testing"
+
+func newTestEntityWithProps(names ...string) *Entity {
+	sc := &ServerClass{name: "TestClass"}
+	e := &Entity{serverClass: sc}
+	for _, name := range names {
+		e.props = append(e.props, Property{entry: &flattenedPropEntry{name: name}})
+	}
+	return e
+}
+
+func TestCompilePredicate(t *testing.T) {
+	cases := []struct {
+		raw     string
+		wantOp  queryOp
+		operand int
+	}{
+		{">0", opGt, 0},
+		{">=3", opGte, 3},
+		{"<=3", opLte, 3},
+		{"==5", opEq, 5},
+		{"!=5", opNeq, 5},
+		{"<10", opLt, 10},
+	}
+
+	for _, c := range cases {
+		pred, err := compilePredicate(c.raw)
+		if err != nil {
+			t.Fatalf("compilePredicate(%q) returned error: %v", c.raw, err)
+		}
+		if pred.op != c.wantOp || pred.operand != c.operand {
+			t.Errorf("compilePredicate(%q) = %+v, want op %v operand %d", c.raw, pred, c.wantOp, c.operand)
+		}
+	}
+}
+
+func TestCompilePredicateInvalid(t *testing.T) {
+	cases := []string{"", "banana", ">nope"}
+	for _, raw := range cases {
+		if _, err := compilePredicate(raw); err == nil {
+			t.Errorf("compilePredicate(%q) expected error, got nil", raw)
+		}
+	}
+}
+
+func TestCompileQueryUnterminatedPredicate(t *testing.T) {
+	if _, err := compileQuery("m_iAmmo[>0"); err == nil {
+		t.Error("expected error for unterminated predicate")
+	}
+}
+
+func TestCompileQueryEmptyPath(t *testing.T) {
+	if _, err := compileQuery("[>0]"); err == nil {
+		t.Error("expected error for empty prop path")
+	}
+}
+
+func TestEntityQueryExactMatch(t *testing.T) {
+	e := newTestEntityWithProps("m_iHealth", "m_iAmmo")
+	e.props[0].value = PropertyValue{IntVal: 100}
+
+	matches, err := e.Query("m_iHealth")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Name() != "m_iHealth" {
+		t.Fatalf("Query(\"m_iHealth\") = %v, want single match on m_iHealth", matches)
+	}
+}
+
+func TestEntityQueryWildcard(t *testing.T) {
+	e := newTestEntityWithProps("m_hMyWeapons.000", "m_hMyWeapons.001", "m_iHealth")
+
+	matches, err := e.Query("m_hMyWeapons.*")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Query(\"m_hMyWeapons.*\") returned %d matches, want 2", len(matches))
+	}
+}
+
+func TestEntityQueryPredicate(t *testing.T) {
+	e := newTestEntityWithProps("m_iAmmo.000", "m_iAmmo.001")
+	e.props[0].value = PropertyValue{IntVal: 30}
+	e.props[1].value = PropertyValue{IntVal: 0}
+
+	matches, err := e.Query("m_iAmmo.*[>0]")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Name() != "m_iAmmo.000" {
+		t.Fatalf("Query(\"m_iAmmo.*[>0]\") = %v, want single match on m_iAmmo.000", matches)
+	}
+}
+
+func TestEntityQueryVectorSubPathDoesNotMatch(t *testing.T) {
+	e := newTestEntityWithProps("m_vecOrigin")
+
+	matches, err := e.Query("m_vecOrigin.X")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("Query(\"m_vecOrigin.X\") = %v, want no matches (vector sub-paths aren't real prop names)", matches)
+	}
+}
+
+func TestEntityQueryCachesPlanPerServerClass(t *testing.T) {
+	e := newTestEntityWithProps("m_iHealth")
+
+	if _, err := e.Query("m_iHealth"); err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+
+	e.serverClass.queryPlansMu.Lock()
+	_, ok := e.serverClass.queryPlans["m_iHealth"]
+	e.serverClass.queryPlansMu.Unlock()
+
+	if !ok {
+		t.Error("expected compiled plan to be cached on the entity's server-class")
+	}
+}
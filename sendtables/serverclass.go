@@ -0,0 +1,37 @@
+package sendtables
+
+import "sync"
+
+// ServerClass stores meta information about a networked entity class, such
+// as "CCSPlayer" or "CWeaponAK47", including its flattened property table.
+type ServerClass struct {
+	id     int
+	name   string
+	dtName string
+
+	flattenedProps []flattenedPropEntry
+
+	customDecoders   map[string]PropertyDecoderFunc
+	customDecodersMu sync.RWMutex
+
+	queryPlans   map[string]*queryPlan
+	queryPlansMu sync.Mutex
+
+	metricsCollector MetricsCollector
+	tickProvider     func() int
+}
+
+// ID returns the server-class's unique id.
+func (sc *ServerClass) ID() int {
+	return sc.id
+}
+
+// Name returns the server-class's name (e.g. "CCSPlayer").
+func (sc *ServerClass) Name() string {
+	return sc.name
+}
+
+// DataTableName returns the name of the underlying data table.
+func (sc *ServerClass) DataTableName() string {
+	return sc.dtName
+}
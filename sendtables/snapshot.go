@@ -0,0 +1,105 @@
+package sendtables
+
+// EntitySnapshot is a compact, point-in-time copy of an Entity's property
+// values, indexed the same way as Entity.props. It holds no reference back
+// to the Entity it was taken from, so it's cheap to keep around as a
+// keyframe for later restoration.
+type EntitySnapshot struct {
+	serverClass *ServerClass
+	values      []PropertyValue
+}
+
+// ServerClass returns the server-class the snapshot was taken from. Restore
+// will panic if used against an Entity of a different server-class.
+func (s EntitySnapshot) ServerClass() *ServerClass {
+	return s.serverClass
+}
+
+// Snapshot captures the current value of every property on the Entity.
+//
+// Snapshots are intended to be taken periodically (e.g. every N ticks) as
+// keyframes, so that a parser can later Restore the world state at the
+// nearest keyframe instead of re-decoding a demo from the start - the
+// foundation for random-access seeking.
+func (e *Entity) Snapshot() EntitySnapshot {
+	values := make([]PropertyValue, len(e.props))
+	for i := range e.props {
+		values[i] = e.props[i].value
+	}
+
+	return EntitySnapshot{
+		serverClass: e.serverClass,
+		values:      values,
+	}
+}
+
+// Restore replaces the Entity's current property values with the ones held
+// in snap and fires firePropertyUpdate for every one of them, in prop-index
+// order, so that bound variables (Property.Bind / BindStruct) and OnUpdate
+// handlers observe the restored state exactly as if the values had just
+// been decoded off the wire, one after another in the same order they'd be
+// decoded in.
+//
+// Panics if snap was taken from an Entity of a different server-class, since
+// prop indices are only meaningful within a single server-class's flattened
+// prop table.
+func (e *Entity) Restore(snap EntitySnapshot) {
+	if snap.serverClass != e.serverClass {
+		panic("Restore: snapshot was taken from an entity of a different server-class")
+	}
+
+	for idx, val := range snap.values {
+		prop := &e.props[idx]
+		journaling := prop.journal != nil
+
+		var oldValue PropertyValue
+		if journaling {
+			oldValue = prop.value
+		}
+
+		prop.value = val
+		prop.firePropertyUpdate()
+
+		if journaling {
+			prop.recordChange(e, e.serverClass.tick(), oldValue, val)
+		}
+	}
+}
+
+// WorldSnapshot is a point-in-time capture of every tracked Entity's
+// EntitySnapshot, keyed by entity id. It's the parser-level counterpart to
+// EntitySnapshot: a parser tracking a whole world of entities (e.g. in a
+// map[int]*Entity keyed by Entity.ID) can capture one of these periodically
+// and later Restore back to it, instead of re-decoding the demo from the
+// start - the foundation for random-access seeking across the whole game
+// state, not just a single entity.
+type WorldSnapshot struct {
+	entities map[int]EntitySnapshot
+}
+
+// CaptureWorld builds a WorldSnapshot of every entity in entities (keyed by
+// Entity.ID, as a parser would track its live entity set).
+func CaptureWorld(entities map[int]*Entity) WorldSnapshot {
+	snaps := make(map[int]EntitySnapshot, len(entities))
+	for id, e := range entities {
+		snaps[id] = e.Snapshot()
+	}
+
+	return WorldSnapshot{entities: snaps}
+}
+
+// Restore restores every entity present in both ws and entities (matched by
+// id) to its snapshotted state, via Entity.Restore.
+//
+// Entities present in ws but missing from entities - e.g. destroyed since
+// the snapshot was taken - are silently skipped: a parser seeking backward
+// past a snapshot is expected to recreate them from the demo's own
+// create/destroy stream, not from WorldSnapshot, since WorldSnapshot only
+// ever captures property values, not entity lifetime.
+func (ws WorldSnapshot) Restore(entities map[int]*Entity) {
+	for id, snap := range ws.entities {
+		if e, ok := entities[id]; ok {
+			e.Restore(snap)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package sendtables
+
+import "testing"
+
+func newSnapshotTestEntity() *Entity {
+	return &Entity{
+		serverClass: &ServerClass{name: "TestClass"},
+		props: []Property{
+			{entry: &flattenedPropEntry{name: "m_iHealth"}, value: PropertyValue{IntVal: 100}},
+			{entry: &flattenedPropEntry{name: "m_iAmmo"}, value: PropertyValue{IntVal: 30}},
+		},
+	}
+}
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	e := newSnapshotTestEntity()
+	snap := e.Snapshot()
+
+	e.props[0].value = PropertyValue{IntVal: 0}
+	e.props[1].value = PropertyValue{IntVal: 0}
+
+	e.Restore(snap)
+
+	if e.props[0].value.IntVal != 100 {
+		t.Errorf("m_iHealth after Restore = %d, want 100", e.props[0].value.IntVal)
+	}
+	if e.props[1].value.IntVal != 30 {
+		t.Errorf("m_iAmmo after Restore = %d, want 30", e.props[1].value.IntVal)
+	}
+}
+
+func TestRestoreFiresUpdateHandlers(t *testing.T) {
+	e := newSnapshotTestEntity()
+	snap := e.Snapshot()
+
+	var fired int
+	e.props[0].OnUpdate(func(val PropertyValue) { fired = val.IntVal })
+
+	e.props[0].value = PropertyValue{IntVal: 1}
+	e.Restore(snap)
+
+	if fired != 100 {
+		t.Errorf("OnUpdate handler observed %d after Restore, want 100", fired)
+	}
+}
+
+func TestRestorePanicsOnServerClassMismatch(t *testing.T) {
+	e1 := newSnapshotTestEntity()
+	snap := e1.Snapshot()
+
+	e2 := newSnapshotTestEntity() // distinct *ServerClass from e1's
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Restore to panic on a server-class mismatch")
+		}
+	}()
+
+	e2.Restore(snap)
+}
+
+func TestSnapshotIsIndependentOfLaterMutation(t *testing.T) {
+	e := newSnapshotTestEntity()
+	snap := e.Snapshot()
+
+	e.props[0].value = PropertyValue{IntVal: 999}
+
+	if snap.values[0].IntVal != 100 {
+		t.Errorf("snapshot value mutated after entity changed, got %d want 100", snap.values[0].IntVal)
+	}
+}
+
+func TestWorldSnapshotRestoreRoundTrip(t *testing.T) {
+	e1 := newSnapshotTestEntity()
+	e1.id = 1
+	e2 := newSnapshotTestEntity()
+	e2.id = 2
+	e2.props[0].value = PropertyValue{IntVal: 50}
+
+	entities := map[int]*Entity{1: e1, 2: e2}
+	world := CaptureWorld(entities)
+
+	e1.props[0].value = PropertyValue{IntVal: 0}
+	e2.props[0].value = PropertyValue{IntVal: 0}
+
+	world.Restore(entities)
+
+	if e1.props[0].value.IntVal != 100 {
+		t.Errorf("e1.m_iHealth after Restore = %d, want 100", e1.props[0].value.IntVal)
+	}
+	if e2.props[0].value.IntVal != 50 {
+		t.Errorf("e2.m_iHealth after Restore = %d, want 50", e2.props[0].value.IntVal)
+	}
+}
+
+func TestWorldSnapshotRestoreSkipsMissingEntities(t *testing.T) {
+	e1 := newSnapshotTestEntity()
+	e1.id = 1
+
+	world := CaptureWorld(map[int]*Entity{1: e1})
+
+	// e1 destroyed: the live set no longer has key 1, only a different
+	// entity under key 2 - Restore must skip snapshot id 1 and leave e2
+	// untouched.
+	e2 := newSnapshotTestEntity()
+	e2.id = 1
+	e2.props[0].value = PropertyValue{IntVal: 7}
+
+	world.Restore(map[int]*Entity{2: e2})
+
+	if e2.props[0].value.IntVal != 7 {
+		t.Errorf("e2.m_iHealth = %d, want unchanged 7 (id 1 was missing from the restore set)", e2.props[0].value.IntVal)
+	}
+}